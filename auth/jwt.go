@@ -0,0 +1,53 @@
+// Package auth issues and validates the HS256 JWTs that authenticate API
+// requests, and provides the fiber middleware that stashes the caller's
+// user ID for handlers to read.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken covers every way a bearer token can fail to validate:
+// bad signature, wrong algorithm, or expiry.
+var ErrInvalidToken = errors.New("auth: invalid or expired token")
+
+// Claims is the JWT payload: the registered claims plus the user ID the
+// rest of the API scopes ownership checks against.
+type Claims struct {
+	UserID string `json:"userId"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken signs a Claims token for userID, valid for ttl.
+func GenerateToken(secret []byte, userID string, ttl time.Duration) (string, error) {
+	claims := Claims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// ParseToken verifies tokenString's signature and expiry and returns its
+// claims.
+func ParseToken(secret []byte, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return secret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LocalsUserID is the c.Locals key the middleware stores the caller's user
+// ID under.
+const LocalsUserID = "userID"
+
+// RequireAuth rejects requests without a valid `Authorization: Bearer`
+// token and stashes the caller's user ID in c.Locals(LocalsUserID) for
+// handlers that need it.
+func RequireAuth(secret []byte) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := userIDFromHeader(c, secret)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Missing or invalid bearer token"})
+		}
+
+		c.Locals(LocalsUserID, userID)
+		return c.Next()
+	}
+}
+
+// OptionalAuth stashes the caller's user ID when a valid bearer token is
+// present, but lets the request through either way. Handlers that only
+// change behavior for authenticated callers (e.g. `?mine=true`) use this
+// instead of RequireAuth.
+func OptionalAuth(secret []byte) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if userID, err := userIDFromHeader(c, secret); err == nil {
+			c.Locals(LocalsUserID, userID)
+		}
+		return c.Next()
+	}
+}
+
+func userIDFromHeader(c *fiber.Ctx, secret []byte) (string, error) {
+	tokenString, ok := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+	if !ok || tokenString == "" {
+		return "", ErrInvalidToken
+	}
+
+	claims, err := ParseToken(secret, tokenString)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.UserID, nil
+}
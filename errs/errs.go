@@ -0,0 +1,83 @@
+// Package errs translates MongoDB driver and decoding errors into
+// consistent HTTP error responses so handlers never leak driver internals
+// to clients.
+package errs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Response is the JSON body written for every translated error: a stable,
+// machine-readable code plus a correlation ID that ties the response back
+// to the server log line carrying the underlying error.
+type Response struct {
+	Code          string `json:"code"`
+	Message       string `json:"message,omitempty"`
+	CorrelationID string `json:"correlationId"`
+}
+
+// messages holds the static, client-facing text for each code. Never echo
+// err.Error() back to the caller: it can carry raw Mongo driver internals
+// (duplicate-key index dumps, connection strings, ...) that this package
+// exists to keep off the wire.
+var messages = map[string]string{
+	"ALREADY_EXISTS":    "A resource with the same unique fields already exists",
+	"NOT_FOUND":         "The requested resource was not found",
+	"DEADLINE_EXCEEDED": "The request took too long to complete",
+	"DATA_CAPS":         "The request body or stored data is malformed",
+	"INTERNAL":          "An internal error occurred",
+}
+
+// Write translates err into the matching HTTP status and code, logs the
+// raw error against a fresh correlation ID, and writes a JSON response
+// carrying only the static message for that code. Handlers should route
+// every error through here instead of returning it bare or reaching for a
+// generic 500.
+func Write(c *fiber.Ctx, err error) error {
+	status, code := translate(err)
+	correlationID := uuid.NewString()
+
+	log.Printf("[%s] %s: %v", correlationID, code, err)
+
+	return c.Status(status).JSON(Response{
+		Code:          code,
+		Message:       messages[code],
+		CorrelationID: correlationID,
+	})
+}
+
+func translate(err error) (status int, code string) {
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		return fiber.StatusConflict, "ALREADY_EXISTS"
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return fiber.StatusNotFound, "NOT_FOUND"
+	case errors.Is(err, context.DeadlineExceeded):
+		return fiber.StatusGatewayTimeout, "DEADLINE_EXCEEDED"
+	case isDecodingError(err):
+		return fiber.StatusUnprocessableEntity, "DATA_CAPS"
+	default:
+		return fiber.StatusInternalServerError, "INTERNAL"
+	}
+}
+
+// isDecodingError reports whether err came from parsing the request body
+// or decoding a BSON document, rather than from the database itself.
+func isDecodingError(err error) bool {
+	var bsonDecodeErr *bsoncodec.DecodeError
+	if errors.As(err, &bsonDecodeErr) {
+		return true
+	}
+
+	var jsonSyntaxErr *json.SyntaxError
+	var jsonTypeErr *json.UnmarshalTypeError
+	return errors.As(err, &jsonSyntaxErr) || errors.As(err, &jsonTypeErr)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"time"
+
+	"GO-rest-api/auth"
+	"GO-rest-api/errs"
+	"GO-rest-api/models"
+	"GO-rest-api/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tokenTTL is how long an issued JWT stays valid.
+const tokenTTL = 24 * time.Hour
+
+// AuthHandler serves the /api/auth routes.
+type AuthHandler struct {
+	Repository repository.UserRepository
+	Secret     []byte
+}
+
+// NewAuthHandler wires an AuthHandler to its repository and the secret used
+// to sign issued JWTs.
+func NewAuthHandler(repo repository.UserRepository, secret []byte) *AuthHandler {
+	return &AuthHandler{Repository: repo, Secret: secret}
+}
+
+func (h *AuthHandler) Register(c *fiber.Ctx) error {
+	dto := new(models.RegisterDTO)
+
+	if err := c.BodyParser(dto); err != nil {
+		return errs.Write(c, err)
+	}
+	if err := validate.Struct(dto); err != nil {
+		return c.Status(422).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(dto.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return errs.Write(c, err)
+	}
+
+	user := &models.User{
+		Email:        dto.Email,
+		PasswordHash: string(passwordHash),
+		CreatedAt:    time.Now(),
+	}
+
+	id, err := h.Repository.Create(c.Context(), user)
+	if err != nil {
+		return errs.Write(c, err)
+	}
+	user.ID = id
+
+	token, err := auth.GenerateToken(h.Secret, user.ID.Hex(), tokenTTL)
+	if err != nil {
+		return errs.Write(c, err)
+	}
+
+	return c.Status(201).JSON(fiber.Map{"token": token})
+}
+
+func (h *AuthHandler) Login(c *fiber.Ctx) error {
+	dto := new(models.LoginDTO)
+
+	if err := c.BodyParser(dto); err != nil {
+		return errs.Write(c, err)
+	}
+	if err := validate.Struct(dto); err != nil {
+		return c.Status(422).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	user, err := h.Repository.FindByEmail(c.Context(), dto.Email)
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid email or password"})
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(dto.Password)); err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "Invalid email or password"})
+	}
+
+	token, err := auth.GenerateToken(h.Secret, user.ID.Hex(), tokenTTL)
+	if err != nil {
+		return errs.Write(c, err)
+	}
+
+	return c.JSON(fiber.Map{"token": token})
+}
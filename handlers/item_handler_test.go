@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"GO-rest-api/auth"
+	"GO-rest-api/models"
+	"GO-rest-api/repository"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fakeItemRepository is an in-memory repository.ItemRepository, letting
+// ItemHandler's behavior be tested without a live Mongo instance.
+type fakeItemRepository struct {
+	items map[primitive.ObjectID]*models.Item
+}
+
+var _ repository.ItemRepository = (*fakeItemRepository)(nil)
+
+func newFakeItemRepository() *fakeItemRepository {
+	return &fakeItemRepository{items: map[primitive.ObjectID]*models.Item{}}
+}
+
+func (f *fakeItemRepository) Create(ctx context.Context, item *models.Item) (primitive.ObjectID, error) {
+	id := primitive.NewObjectID()
+	stored := *item
+	stored.ID = id
+	f.items[id] = &stored
+	return id, nil
+}
+
+func (f *fakeItemRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Item, error) {
+	item, ok := f.items[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	found := *item
+	return &found, nil
+}
+
+func (f *fakeItemRepository) Find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]bson.M, error) {
+	return nil, nil
+}
+
+func (f *fakeItemRepository) Update(ctx context.Context, id primitive.ObjectID, update bson.M) error {
+	if _, ok := f.items[id]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (f *fakeItemRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	delete(f.items, id)
+	return nil
+}
+
+func (f *fakeItemRepository) Watch(ctx context.Context, opts *options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return nil, nil
+}
+
+func TestCreateItem(t *testing.T) {
+	repo := newFakeItemRepository()
+	h := NewItemHandler(repo)
+
+	app := fiber.New()
+	app.Post("/api/items", h.CreateItem)
+
+	body := `{"title":"Widget","description":"A widget","price":"9.99","category":"electronics"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusCreated)
+	}
+
+	var got models.Item
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Title != "Widget" {
+		t.Errorf("Title = %q, want %q", got.Title, "Widget")
+	}
+	if len(repo.items) != 1 {
+		t.Errorf("repo has %d items, want 1", len(repo.items))
+	}
+}
+
+func TestGetItemNotFound(t *testing.T) {
+	repo := newFakeItemRepository()
+	h := NewItemHandler(repo)
+
+	app := fiber.New()
+	app.Get("/api/items/:id", h.GetItem)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/items/"+primitive.NewObjectID().Hex(), nil)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusNotFound)
+	}
+}
+
+func TestUpdateItemForbiddenForNonOwner(t *testing.T) {
+	repo := newFakeItemRepository()
+	id := primitive.NewObjectID()
+	repo.items[id] = &models.Item{
+		ID:      id,
+		Title:   "Widget",
+		Price:   models.Price{Decimal: decimal.NewFromInt(5)},
+		OwnerID: "owner-1",
+	}
+
+	h := NewItemHandler(repo)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(auth.LocalsUserID, "someone-else")
+		return c.Next()
+	})
+	app.Patch("/api/items/:id", h.UpdateItem)
+
+	body := `{"title":"New title"}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/items/"+id.Hex(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusForbidden)
+	}
+}
+
+// TestUpdateItemForbiddenForLegacyUnownedItem locks in that a pre-chunk0-7
+// item with no OwnerID is denied to every caller, rather than treated as
+// open to anyone who happens to be authenticated.
+func TestUpdateItemForbiddenForLegacyUnownedItem(t *testing.T) {
+	repo := newFakeItemRepository()
+	id := primitive.NewObjectID()
+	repo.items[id] = &models.Item{
+		ID:    id,
+		Title: "Widget",
+		Price: models.Price{Decimal: decimal.NewFromInt(5)},
+	}
+
+	h := NewItemHandler(repo)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(auth.LocalsUserID, "any-authenticated-user")
+		return c.Next()
+	})
+	app.Patch("/api/items/:id", h.UpdateItem)
+
+	body := `{"title":"New title"}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/items/"+id.Hex(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusForbidden)
+	}
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// changeEvent is the subset of a MongoDB change event GetItemsStream cares
+// about.
+type changeEvent struct {
+	OperationType string                 `bson:"operationType" json:"operationType"`
+	DocumentKey   map[string]interface{} `bson:"documentKey" json:"documentKey"`
+	FullDocument  map[string]interface{} `bson:"fullDocument" json:"fullDocument"`
+}
+
+// streamEvent is a changeEvent paired with the resume token it arrived
+// with, ready to be written out as an SSE frame.
+type streamEvent struct {
+	changeEvent
+	resumeToken bson.Raw
+}
+
+// changeStreamHub reads a MongoDB change stream exactly once and broadcasts
+// every event to its current subscribers. Subscribers that fall behind have
+// events dropped rather than stalling the reader for everyone else.
+type changeStreamHub struct {
+	mu          sync.Mutex
+	subscribers map[chan streamEvent]struct{}
+}
+
+func newChangeStreamHub() *changeStreamHub {
+	return &changeStreamHub{subscribers: make(map[chan streamEvent]struct{})}
+}
+
+func (h *changeStreamHub) subscribe() chan streamEvent {
+	ch := make(chan streamEvent, 16)
+
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch
+}
+
+func (h *changeStreamHub) unsubscribe(ch chan streamEvent) {
+	h.mu.Lock()
+	delete(h.subscribers, ch)
+	h.mu.Unlock()
+
+	close(ch)
+}
+
+func (h *changeStreamHub) broadcast(event streamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Println("sse: dropping event for slow consumer")
+		}
+	}
+}
+
+// run drains stream until it closes or the context is cancelled, fanning
+// every decoded event out to subscribers. Call it in its own goroutine.
+func (h *changeStreamHub) run(ctx context.Context, stream *mongo.ChangeStream) {
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var event changeEvent
+		if err := bson.Unmarshal(stream.Current, &event); err != nil {
+			log.Println("sse: decode change event:", err)
+			continue
+		}
+		h.broadcast(streamEvent{changeEvent: event, resumeToken: stream.ResumeToken()})
+	}
+
+	if err := stream.Err(); err != nil {
+		log.Println("sse: change stream closed:", err)
+	}
+}
+
+// GetItemsStream streams create/update/delete events on the items
+// collection as Server-Sent Events. Clients that reconnect with a
+// Last-Event-ID first get replayed everything they missed from a private
+// resumed change stream, then are handed off to the live hub feed. The hub
+// subscription is taken before the replay starts, so an event that arrives
+// during the replay can be sent twice.
+func (h *ItemHandler) GetItemsStream(c *fiber.Ctx) error {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	lastEventID := c.Get("Last-Event-ID")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		sub := h.Hub.subscribe()
+		defer h.Hub.unsubscribe(sub)
+
+		if lastEventID != "" {
+			if err := h.replayFrom(context.Background(), w, lastEventID); err != nil {
+				log.Println("sse: resume failed:", err)
+			}
+		}
+
+		for event := range sub {
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// replayFrom resumes a private change stream from resumeToken and writes
+// out everything currently available before returning, so the live hand-off
+// to the hub doesn't miss anything the caller hasn't already seen.
+func (h *ItemHandler) replayFrom(parent context.Context, w *bufio.Writer, resumeToken string) error {
+	token, err := decodeResumeToken(resumeToken)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(parent, 5*time.Second)
+	defer cancel()
+
+	stream, err := h.Repository.Watch(ctx, options.ChangeStream().SetResumeAfter(token))
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	for stream.TryNext(ctx) {
+		var event changeEvent
+		if err := bson.Unmarshal(stream.Current, &event); err != nil {
+			log.Println("sse: decode change event:", err)
+			continue
+		}
+		if err := writeEvent(w, streamEvent{changeEvent: event, resumeToken: stream.ResumeToken()}); err != nil {
+			return err
+		}
+	}
+
+	return stream.Err()
+}
+
+func writeEvent(w *bufio.Writer, event streamEvent) error {
+	payload, err := json.Marshal(event.changeEvent)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", encodeResumeToken(event.resumeToken), payload); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+func encodeResumeToken(token bson.Raw) string {
+	return base64.URLEncoding.EncodeToString(token)
+}
+
+func decodeResumeToken(id string) (bson.Raw, error) {
+	raw, err := base64.URLEncoding.DecodeString(id)
+	if err != nil {
+		return nil, fmt.Errorf("sse: invalid Last-Event-ID: %w", err)
+	}
+	return bson.Raw(raw), nil
+}
@@ -5,241 +5,116 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strconv"
-	"time"
+
+	"GO-rest-api/auth"
+	"GO-rest-api/handlers"
+	"GO-rest-api/repository"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/joho/godotenv"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-type Item struct {
-	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
-	Title       string             `json:"title,omitempty" bson:"title,omitempty"`
-	Description string             `json:"description,omitempty" bson:"description,omitempty"`
-	Price       string             `json:"price,omitempty" bson:"price,omitempty"`
-	CreatedAt   string             `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
-	Category    string           `json:"category,omitempty" bson:"category,omitempty"`
+// container is the app's small dependency-injection container: it owns the
+// Mongo client and builds every handler that depends on it, so wiring in a
+// second collection, auth middleware, or metrics never means editing
+// main's request plumbing again.
+type container struct {
+	client      *mongo.Client
+	ItemHandler *handlers.ItemHandler
+	AuthHandler *handlers.AuthHandler
+	JWTSecret   []byte
 }
 
-var Items *mongo.Collection
-
-func main() {
-	err := godotenv.Load(".env")
+func newContainer(ctx context.Context, mongoURI, jwtSecret string) (*container, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		return nil, err
 	}
-
-	MOGNODB_URI := os.Getenv("MONGODB_URI")
-	clientOptions := options.Client().ApplyURI(MOGNODB_URI)
-	client, err := mongo.Connect(context.Background(), clientOptions)
-
-	if err != nil {
-		log.Fatal(err)
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
 	}
-	defer client.Disconnect(context.Background())
 
-	err = client.Ping(context.Background(), nil)
+	itemsCollection := client.Database("go-mongo").Collection("items")
+	itemRepo := repository.NewMongoItemRepository(itemsCollection)
 
-	if err != nil {
-		log.Fatal(err)
+	if err := itemRepo.EnsureIndexes(ctx); err != nil {
+		return nil, err
 	}
-
-	fmt.Println("Connected to MongoDB!")
-
-	Items = client.Database("go-mongo").Collection("items")
-
-	app := fiber.New()
-
-	app.Get("/api/items", getItems)
-	app.Post("api/items", createItem)
-	app.Get("api/items/:id", getItem)
-	app.Patch("api/items/:id", updateItem)
-	app.Delete("api/items/:id", deleteTodo)
-
-	PORT := os.Getenv("PORT")
-	if PORT == "" {
-		PORT = "3000"
+	if err := itemRepo.MigrateLegacyPrices(ctx); err != nil {
+		return nil, err
 	}
-
-	log.Fatal(app.Listen(PORT))
-}
-
-func getItems(c *fiber.Ctx) error {
-	var items []Item
-
-	page := c.Query("page"); if page == "" { page = "1" }
-
-	sortBy := c.Query("sortBy")
-	if sortBy == "" {
-		sortBy = "createdAt"
-	}
-	sortOrder := c.Query("sortOrder")
-	if sortOrder == "" {
-		sortOrder = "desc"
+	if err := itemRepo.MigrateLegacyCreatedAt(ctx); err != nil {
+		return nil, err
 	}
-	category := c.Query("category")
-
-	filter := bson.M{}
 
-	if category != "" {
-		filter = bson.M{"category": category}
+	itemHandler := handlers.NewItemHandler(itemRepo)
+	if err := itemHandler.StartStream(ctx); err != nil {
+		return nil, err
 	}
 
-	var sort = bson.D{}
-
-	if sortOrder == "desc" {
-		sort = bson.D{{Key: sortBy, Value: -1}}
-	} else {
-		sort = bson.D{{Key: sortBy, Value: 1}}
-	}
-
-	pageInt, err := strconv.Atoi(page)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid page number"})
-	}
-
-	limit := int64(10 * pageInt)
-	log.Println(limit)
-
-	cursor, err := Items.Find(context.Background(), filter, &options.FindOptions{
-		Sort: sort,
-		Limit: &limit,
-	})
-	if err != nil {
-		return err
-	}
-
-	defer cursor.Close(context.Background())
-
-	for cursor.Next(context.Background()) {
-		var item Item
-		if err := cursor.Decode(&item); err != nil {
-			return err
-		}
-		items = append(items, item)
-	}
-
-	return c.JSON(items)
-}
-
-func getItem(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objectID, err := primitive.ObjectIDFromHex(id)
+	usersCollection := client.Database("go-mongo").Collection("users")
+	userRepo := repository.NewMongoUserRepository(usersCollection)
 
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
+	if err := userRepo.EnsureIndexes(ctx); err != nil {
+		return nil, err
 	}
 
-	cursor := Items.FindOne(context.Background(), bson.M{"_id": objectID})
-
-	item := &Item{}
-	if err := cursor.Decode(item); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error fetching item"})
-	}
+	secret := []byte(jwtSecret)
+	authHandler := handlers.NewAuthHandler(userRepo, secret)
 
-	return c.JSON(item)
+	return &container{
+		client:      client,
+		ItemHandler: itemHandler,
+		AuthHandler: authHandler,
+		JWTSecret:   secret,
+	}, nil
 }
 
-func createItem(c *fiber.Ctx) error {
-	item := new(Item)
-
-	if err := c.BodyParser(item); err != nil {
-		return err
-	}
-
-	if item.Title == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Title is required"})
-	}
-	if item.Description == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Description is required"})
-	}
-	if item.Price == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Price is required"})
-	}
-	if item.Category == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Category is required"})
-	}
-
-	item.CreatedAt = time.Now().GoString()
-
-	insertResult, err := Items.InsertOne(context.Background(), item)
-
-	if err != nil {
-		return err
-	}
-
-	item.ID = insertResult.InsertedID.(primitive.ObjectID)
-
-	return c.Status(201).JSON(item)
+func (di *container) Close(ctx context.Context) error {
+	return di.client.Disconnect(ctx)
 }
 
-func updateItem(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objectID, err := primitive.ObjectIDFromHex(id)
-	newItem := new(Item)
-
-	if err := c.BodyParser(newItem); err != nil {
-		return err
-	}
-
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
-
-	cursor := Items.FindOne(context.Background(), bson.M{"_id": objectID})
-
-	item := &Item{}
-	if err := cursor.Decode(item); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Error fetching item"})
+func main() {
+	if err := godotenv.Load(".env"); err != nil {
+		log.Fatal("Error loading .env file")
 	}
 
-	if (newItem.Title == "") && (newItem.Description == "") && (newItem.Price == "") && (newItem.Category == "") {
-		return c.Status(400).JSON(fiber.Map{"error": "Title, Description, Price or Category is required"})
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		// Never fall back to a secret baked into this (public) source tree:
+		// anyone who reads it could forge a valid token for any userID and
+		// defeat every ownership check the auth layer exists to enforce.
+		log.Fatal("JWT_SECRET must be set")
 	}
 
-	if newItem.Title != "" {
-		item.Title = newItem.Title
-	}
-	if newItem.Description != "" {
-		item.Description = newItem.Description
-	}
-	if newItem.Price != "" {
-		item.Price = newItem.Price
-	}
-	if newItem.Category != "" {
-		item.Category = newItem.Category
-	}
-
-	filter := bson.M{"_id": objectID}
-	update := bson.M{"$set": bson.M{"title": item.Title, "description": item.Description, "price": item.Price, "category": item.Category}}
-
-	_, err = Items.UpdateOne(context.Background(), filter, update)
+	ctx := context.Background()
 
+	di, err := newContainer(ctx, os.Getenv("MONGODB_URI"), jwtSecret)
 	if err != nil {
-		return err
+		log.Fatal(err)
 	}
-	return c.Status(200).JSON(fiber.Map{"message": "success"})
-}
+	defer di.Close(ctx)
 
-func deleteTodo(c *fiber.Ctx) error {
-	id := c.Params("id")
-	objectID, err := primitive.ObjectIDFromHex(id)
+	fmt.Println("Connected to MongoDB!")
 
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid ID"})
-	}
+	app := fiber.New()
 
-	filter := bson.M{"_id": objectID}
+	app.Post("/api/auth/register", di.AuthHandler.Register)
+	app.Post("/api/auth/login", di.AuthHandler.Login)
 
-	_, err = Items.DeleteOne(context.Background(), filter)
+	app.Get("/api/items", auth.OptionalAuth(di.JWTSecret), di.ItemHandler.GetItems)
+	app.Get("/api/items/stream", di.ItemHandler.GetItemsStream)
+	app.Post("api/items", auth.RequireAuth(di.JWTSecret), di.ItemHandler.CreateItem)
+	app.Get("api/items/:id", di.ItemHandler.GetItem)
+	app.Patch("api/items/:id", auth.RequireAuth(di.JWTSecret), di.ItemHandler.UpdateItem)
+	app.Delete("api/items/:id", auth.RequireAuth(di.JWTSecret), di.ItemHandler.DeleteItem)
 
-	if err != nil {
-		return err
+	PORT := os.Getenv("PORT")
+	if PORT == "" {
+		PORT = "3000"
 	}
 
-	return c.Status(200).JSON(fiber.Map{"message": "success"})
+	log.Fatal(app.Listen(PORT))
 }
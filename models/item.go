@@ -0,0 +1,111 @@
+// Package models holds the API's domain types, independent of how they're
+// stored (repository) or served (handlers).
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"GO-rest-api/utils/hal"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category is a bounded set of item categories; anything outside
+// AllowedCategories is rejected by CreateItemDTO/UpdateItemDTO validation.
+type Category string
+
+const (
+	CategoryElectronics Category = "electronics"
+	CategoryGroceries   Category = "groceries"
+	CategoryClothing    Category = "clothing"
+	CategoryBooks       Category = "books"
+	CategoryToys        Category = "toys"
+	CategoryOther       Category = "other"
+)
+
+// AllowedCategories is the whitelist enforced by the `oneof` tag below.
+var AllowedCategories = []Category{
+	CategoryElectronics, CategoryGroceries, CategoryClothing, CategoryBooks, CategoryToys, CategoryOther,
+}
+
+// Price wraps decimal.Decimal so it can carry its own BSON representation:
+// it reads either the legacy free-form string or a decimal128, and always
+// writes a decimal128.
+type Price struct {
+	decimal.Decimal
+}
+
+func (p Price) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	d128, err := primitive.ParseDecimal128(p.Decimal.String())
+	if err != nil {
+		return 0, nil, fmt.Errorf("price: %w", err)
+	}
+	return bson.MarshalValue(d128)
+}
+
+func (p *Price) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.Decimal128:
+		var d128 primitive.Decimal128
+		if err := bson.UnmarshalValue(t, data, &d128); err != nil {
+			return err
+		}
+		parsed, err := decimal.NewFromString(d128.String())
+		if err != nil {
+			return fmt.Errorf("price: %w", err)
+		}
+		p.Decimal = parsed
+		return nil
+	case bsontype.String:
+		var s string
+		if err := bson.UnmarshalValue(t, data, &s); err != nil {
+			return err
+		}
+		parsed, err := decimal.NewFromString(s)
+		if err != nil {
+			return fmt.Errorf("price: %w", err)
+		}
+		p.Decimal = parsed
+		return nil
+	default:
+		return fmt.Errorf("price: unsupported bson type %s", t)
+	}
+}
+
+type Item struct {
+	ID          primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Title       string             `json:"title,omitempty" bson:"title,omitempty"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	Price       Price              `json:"price" bson:"price"`
+	CreatedAt   time.Time          `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
+	Category    Category           `json:"category,omitempty" bson:"category,omitempty"`
+	OwnerID     string             `json:"ownerId,omitempty" bson:"ownerId,omitempty"`
+	Links       hal.Links          `json:"_links,omitempty" bson:"-"`
+}
+
+// CreateItemDTO is the request body accepted by createItem. Its tags
+// replace the hand-written `if x == ""` checks the handler used to do.
+type CreateItemDTO struct {
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description" validate:"required"`
+	Price       string `json:"price" validate:"required"`
+	Category    string `json:"category" validate:"required,oneof=electronics groceries clothing books toys other"`
+}
+
+// UpdateItemDTO is the request body accepted by updateItem. Every field is
+// optional; the handler still rejects a body where none were set.
+type UpdateItemDTO struct {
+	Title       string `json:"title,omitempty" validate:"omitempty,min=1"`
+	Description string `json:"description,omitempty" validate:"omitempty,min=1"`
+	Price       string `json:"price,omitempty" validate:"omitempty"`
+	Category    string `json:"category,omitempty" validate:"omitempty,oneof=electronics groceries clothing books toys other"`
+}
+
+// Empty reports whether the caller set none of the optional fields.
+func (dto UpdateItemDTO) Empty() bool {
+	return dto.Title == "" && dto.Description == "" && dto.Price == "" && dto.Category == ""
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// User is a registered account. PasswordHash is never serialized back to
+// clients.
+type User struct {
+	ID           primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Email        string             `json:"email,omitempty" bson:"email,omitempty"`
+	PasswordHash string             `json:"-" bson:"passwordHash,omitempty"`
+	CreatedAt    time.Time          `json:"createdAt,omitempty" bson:"createdAt,omitempty"`
+}
+
+// RegisterDTO is the request body accepted by POST /api/auth/register.
+type RegisterDTO struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// LoginDTO is the request body accepted by POST /api/auth/login.
+type LoginDTO struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
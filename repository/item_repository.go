@@ -0,0 +1,99 @@
+// Package repository isolates every MongoDB call behind the ItemRepository
+// interface, so handlers can be unit tested against a fake and the real
+// implementation can be tested on its own against a live Mongo instance.
+package repository
+
+import (
+	"context"
+
+	"GO-rest-api/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ItemRepository is the data-access surface handlers.ItemHandler depends on.
+type ItemRepository interface {
+	Create(ctx context.Context, item *models.Item) (primitive.ObjectID, error)
+	FindByID(ctx context.Context, id primitive.ObjectID) (*models.Item, error)
+	Find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]bson.M, error)
+	Update(ctx context.Context, id primitive.ObjectID, update bson.M) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	Watch(ctx context.Context, opts *options.ChangeStreamOptions) (*mongo.ChangeStream, error)
+}
+
+// MongoItemRepository is the ItemRepository backed by a real `items`
+// collection.
+type MongoItemRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoItemRepository wraps an already-connected items collection.
+func NewMongoItemRepository(collection *mongo.Collection) *MongoItemRepository {
+	return &MongoItemRepository{collection: collection}
+}
+
+func (r *MongoItemRepository) Create(ctx context.Context, item *models.Item) (primitive.ObjectID, error) {
+	result, err := r.collection.InsertOne(ctx, item)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *MongoItemRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*models.Item, error) {
+	item := &models.Item{}
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// Find returns the matching documents as raw bson.M rather than decoded
+// models.Item so callers doing cursor pagination can still pull arbitrary
+// sort-field values out of them.
+func (r *MongoItemRepository) Find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]bson.M, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.M
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := bson.Unmarshal(cursor.Current, &doc); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, cursor.Err()
+}
+
+func (r *MongoItemRepository) Update(ctx context.Context, id primitive.ObjectID, update bson.M) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+func (r *MongoItemRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *MongoItemRepository) Watch(ctx context.Context, opts *options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return r.collection.Watch(ctx, mongo.Pipeline{}, opts)
+}
+
+// EnsureIndexes creates the unique title+category index the duplicate-key
+// error path relies on. It's a one-time startup step, not part of the
+// steady-state ItemRepository interface.
+func (r *MongoItemRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "title", Value: 1}, {Key: "category", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
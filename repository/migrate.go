@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MigrateLegacyPrices rewrites every document whose price is still the old
+// free-form string into a BSON decimal128, so callers can rely on a single
+// on-disk representation going forward instead of branching on BSON type.
+// A string that doesn't parse as a decimal is logged and left as it is.
+// It's a one-time startup step, not part of the steady-state
+// ItemRepository interface.
+func (r *MongoItemRepository) MigrateLegacyPrices(ctx context.Context) error {
+	cursor, err := r.collection.Find(ctx, bson.M{"price": bson.M{"$type": "string"}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID    primitive.ObjectID `bson:"_id"`
+			Price string             `bson:"price"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		parsed, err := decimal.NewFromString(doc.Price)
+		if err != nil {
+			log.Printf("MigrateLegacyPrices: skipping %s, unparseable price %q: %v", doc.ID.Hex(), doc.Price, err)
+			continue
+		}
+
+		d128, err := primitive.ParseDecimal128(parsed.String())
+		if err != nil {
+			return err
+		}
+
+		if _, err := r.collection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"price": d128}}); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// goStringDate matches the `time.Date(...)` form time.Time.GoString()
+// produced back when createdAt was still stored as a free-form string.
+var goStringDate = regexp.MustCompile(`time\.Date\((\d+),\s*time\.(\w+),\s*(\d+),\s*(\d+),\s*(\d+),\s*(\d+),\s*(\d+),`)
+
+var goStringMonths = map[string]time.Month{
+	"January": time.January, "February": time.February, "March": time.March,
+	"April": time.April, "May": time.May, "June": time.June,
+	"July": time.July, "August": time.August, "September": time.September,
+	"October": time.October, "November": time.November, "December": time.December,
+}
+
+// parseLegacyCreatedAt recovers a time.Time from the `time.Time.GoString()`
+// output the baseline handler stored createdAt as.
+func parseLegacyCreatedAt(s string) (time.Time, error) {
+	m := goStringDate.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("createdAt %q doesn't match the legacy GoString format", s)
+	}
+
+	month, ok := goStringMonths[m[2]]
+	if !ok {
+		return time.Time{}, fmt.Errorf("createdAt %q has unrecognized month %q", s, m[2])
+	}
+
+	atoi := func(field string) int {
+		n, _ := strconv.Atoi(field)
+		return n
+	}
+
+	return time.Date(atoi(m[1]), month, atoi(m[3]), atoi(m[4]), atoi(m[5]), atoi(m[6]), atoi(m[7]), time.UTC), nil
+}
+
+// MigrateLegacyCreatedAt rewrites every document whose createdAt is still
+// the old GoString-formatted string into a BSON datetime, so reads,
+// updates and deletes of pre-chunk0-5 documents stop failing to decode
+// into models.Item. Unparseable timestamps fall back to the current time
+// rather than leaving the document permanently stuck. It's a one-time
+// startup step, not part of the steady-state ItemRepository interface.
+func (r *MongoItemRepository) MigrateLegacyCreatedAt(ctx context.Context) error {
+	cursor, err := r.collection.Find(ctx, bson.M{"createdAt": bson.M{"$type": "string"}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID        primitive.ObjectID `bson:"_id"`
+			CreatedAt string             `bson:"createdAt"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		parsed, err := parseLegacyCreatedAt(doc.CreatedAt)
+		if err != nil {
+			log.Printf("MigrateLegacyCreatedAt: %s unparseable, defaulting to now: %v", doc.ID.Hex(), err)
+			parsed = time.Now()
+		}
+
+		if _, err := r.collection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"createdAt": parsed}}); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
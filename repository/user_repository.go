@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"GO-rest-api/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserRepository is the data-access surface handlers.AuthHandler depends
+// on.
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) (primitive.ObjectID, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+}
+
+// MongoUserRepository is the UserRepository backed by a real `users`
+// collection.
+type MongoUserRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoUserRepository wraps an already-connected users collection.
+func NewMongoUserRepository(collection *mongo.Collection) *MongoUserRepository {
+	return &MongoUserRepository{collection: collection}
+}
+
+func (r *MongoUserRepository) Create(ctx context.Context, user *models.User) (primitive.ObjectID, error) {
+	result, err := r.collection.InsertOne(ctx, user)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return result.InsertedID.(primitive.ObjectID), nil
+}
+
+func (r *MongoUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	user := &models.User{}
+	if err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// EnsureIndexes creates the unique email index that backs the duplicate
+// registration path. It's a one-time startup step, not part of the
+// steady-state UserRepository interface.
+func (r *MongoUserRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,70 @@
+// Package hal builds HAL+JSON (application/hal+json) response envelopes so
+// the API stays self-descriptive: clients can follow `_links` instead of
+// hardcoding URL templates for related or paged resources.
+package hal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Link is a single HAL link relation.
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links is the `_links` map attached to a resource or collection.
+type Links map[string]Link
+
+// ItemLinks builds the `_links` for a single item resource: `self`,
+// `collection`, and `category` (when the item has one).
+func ItemLinks(baseURL, id, category string) Links {
+	links := Links{
+		"self":       {Href: fmt.Sprintf("%s/api/items/%s", baseURL, id)},
+		"collection": {Href: fmt.Sprintf("%s/api/items", baseURL)},
+	}
+
+	if category != "" {
+		links["category"] = Link{Href: fmt.Sprintf("%s/api/items?category=%s", baseURL, url.QueryEscape(category))}
+	}
+
+	return links
+}
+
+// CollectionLinks builds the `_links` for a paged collection response:
+// `self`, and `next`/`prev` when the corresponding page tokens are set.
+// next/prev reuse every query parameter `self` carries (category, sortBy,
+// sortOrder, pageSize, mine, ...) with only `pageToken` swapped out, so
+// following them preserves the caller's filter/sort/scope instead of
+// resetting it to the unfiltered default collection.
+func CollectionLinks(baseURL, query, nextPageToken, prevPageToken string) Links {
+	links := Links{"self": {Href: baseURL + "/api/items" + query}}
+
+	if nextPageToken != "" {
+		links["next"] = Link{Href: baseURL + "/api/items" + withPageToken(query, nextPageToken)}
+	}
+	if prevPageToken != "" {
+		links["prev"] = Link{Href: baseURL + "/api/items" + withPageToken(query, prevPageToken)}
+	}
+
+	return links
+}
+
+// withPageToken rebuilds query with its pageToken parameter set to token,
+// keeping every other parameter the caller sent.
+func withPageToken(query, token string) string {
+	values, _ := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if values == nil {
+		values = url.Values{}
+	}
+	values.Set("pageToken", token)
+	return "?" + values.Encode()
+}
+
+// Collection is the HAL envelope for a paged list response, embedding the
+// items under `_embedded`.
+type Collection struct {
+	Links    Links       `json:"_links"`
+	Embedded interface{} `json:"_embedded"`
+}
@@ -0,0 +1,171 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// Cursor is the decoded form of an opaque pageToken: the value of the sort
+// field and the _id of the last document on the previous page. Together
+// they let the next request resume a range scan instead of re-counting
+// every document it already skipped.
+type Cursor struct {
+	SortValue interface{}
+	LastID    string
+}
+
+// wireCursor is what actually gets base64-encoded. SortValue alone can't
+// round-trip through JSON: a bare interface{} loses its concrete BSON type,
+// so a primitive.DateTime or primitive.Decimal128 decodes back as a plain
+// string and compares against the wrong BSON type in the Mongo filter.
+// Carrying SortType alongside the raw JSON lets DecodeToken rehydrate the
+// exact Go type the sortBy field's extractor produced.
+type wireCursor struct {
+	SortType  string          `json:"t"`
+	SortValue json.RawMessage `json:"v"`
+	LastID    string          `json:"id"`
+}
+
+// NextPageTokenMap holds, per sortBy field name, the function that pulls a
+// comparable value for that field out of a raw Mongo document. Centralizing
+// the extractors here keeps the value encoded into a token in sync with
+// whatever the next request's range predicate expects, regardless of which
+// field the caller is sorting by.
+var NextPageTokenMap = map[string]func(doc map[string]interface{}) interface{}{
+	"createdAt": func(doc map[string]interface{}) interface{} { return doc["createdAt"] },
+	"title":     func(doc map[string]interface{}) interface{} { return doc["title"] },
+	"price":     func(doc map[string]interface{}) interface{} { return doc["price"] },
+	"category":  func(doc map[string]interface{}) interface{} { return doc["category"] },
+}
+
+// ClampPageSize normalizes a requested page size to (0, MaxPageSize],
+// falling back to DefaultPageSize when size is unset or invalid.
+func ClampPageSize(size int) int64 {
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	return int64(size)
+}
+
+// encodeSortValue tags v with enough type information to rebuild the exact
+// BSON-comparable Go value on decode, instead of letting it fall through to
+// a bare JSON string or number.
+func encodeSortValue(v interface{}) (string, json.RawMessage, error) {
+	var tag string
+	var wire interface{}
+
+	switch val := v.(type) {
+	case primitive.DateTime:
+		tag, wire = "datetime", int64(val)
+	case primitive.Decimal128:
+		tag, wire = "decimal128", val.String()
+	case string:
+		tag, wire = "string", val
+	case int32:
+		tag, wire = "int32", val
+	case int64:
+		tag, wire = "int64", val
+	case float64:
+		tag, wire = "float64", val
+	default:
+		return "", nil, fmt.Errorf("pagination: unsupported sort value type %T", v)
+	}
+
+	raw, err := json.Marshal(wire)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return tag, raw, nil
+}
+
+// decodeSortValue reverses encodeSortValue, rebuilding the concrete Go
+// type the sortBy field's BSON representation requires.
+func decodeSortValue(tag string, raw json.RawMessage) (interface{}, error) {
+	switch tag {
+	case "datetime":
+		var ms int64
+		if err := json.Unmarshal(raw, &ms); err != nil {
+			return nil, err
+		}
+		return primitive.DateTime(ms), nil
+	case "decimal128":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return primitive.ParseDecimal128(s)
+	case "string":
+		var s string
+		err := json.Unmarshal(raw, &s)
+		return s, err
+	case "int32":
+		var n int32
+		err := json.Unmarshal(raw, &n)
+		return n, err
+	case "int64":
+		var n int64
+		err := json.Unmarshal(raw, &n)
+		return n, err
+	case "float64":
+		var n float64
+		err := json.Unmarshal(raw, &n)
+		return n, err
+	default:
+		return nil, fmt.Errorf("pagination: unknown sort value type tag %q", tag)
+	}
+}
+
+// EncodeToken builds the opaque pageToken for the last document of a page,
+// using the extractor registered for sortBy in NextPageTokenMap.
+func EncodeToken(sortBy string, doc map[string]interface{}, lastID string) (string, error) {
+	extract, ok := NextPageTokenMap[sortBy]
+	if !ok {
+		return "", fmt.Errorf("pagination: no token encoder registered for sort field %q", sortBy)
+	}
+
+	tag, sortValue, err := encodeSortValue(extract(doc))
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := json.Marshal(wireCursor{SortType: tag, SortValue: sortValue, LastID: lastID})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeToken reverses EncodeToken, recovering the sort value and last _id
+// a caller should resume after.
+func DecodeToken(token string) (Cursor, error) {
+	var wire wireCursor
+
+	raw, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("pagination: invalid pageToken: %w", err)
+	}
+
+	if err := json.Unmarshal(raw, &wire); err != nil {
+		return Cursor{}, fmt.Errorf("pagination: invalid pageToken: %w", err)
+	}
+
+	sortValue, err := decodeSortValue(wire.SortType, wire.SortValue)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("pagination: invalid pageToken: %w", err)
+	}
+
+	return Cursor{SortValue: sortValue, LastID: wire.LastID}, nil
+}
@@ -0,0 +1,99 @@
+package pagination
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEncodeDecodeTokenRoundTrip(t *testing.T) {
+	price, err := primitive.ParseDecimal128("9.99")
+	if err != nil {
+		t.Fatalf("ParseDecimal128: %v", err)
+	}
+	lastID := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name string
+		doc  map[string]interface{}
+	}{
+		{"createdAt datetime", map[string]interface{}{"createdAt": primitive.DateTime(1690000000000)}},
+		{"price decimal128", map[string]interface{}{"price": price}},
+		{"title string", map[string]interface{}{"title": "Widget"}},
+		{"category string", map[string]interface{}{"category": "electronics"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sortBy string
+			for k := range tt.doc {
+				sortBy = k
+			}
+
+			token, err := EncodeToken(sortBy, tt.doc, lastID)
+			if err != nil {
+				t.Fatalf("EncodeToken: %v", err)
+			}
+
+			cursor, err := DecodeToken(token)
+			if err != nil {
+				t.Fatalf("DecodeToken: %v", err)
+			}
+
+			if cursor.LastID != lastID {
+				t.Errorf("LastID = %q, want %q", cursor.LastID, lastID)
+			}
+
+			want := tt.doc[sortBy]
+			switch w := want.(type) {
+			case primitive.Decimal128:
+				got, ok := cursor.SortValue.(primitive.Decimal128)
+				if !ok {
+					t.Fatalf("SortValue type = %T, want primitive.Decimal128", cursor.SortValue)
+				}
+				if got.String() != w.String() {
+					t.Errorf("SortValue = %s, want %s", got.String(), w.String())
+				}
+			default:
+				if cursor.SortValue != want {
+					t.Errorf("SortValue = %#v, want %#v", cursor.SortValue, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeTokenUnknownSortField(t *testing.T) {
+	_, err := EncodeToken("unknownField", map[string]interface{}{"unknownField": "x"}, primitive.NewObjectID().Hex())
+	if err == nil {
+		t.Fatal("expected an error for a sortBy field with no registered extractor")
+	}
+}
+
+func TestDecodeTokenInvalid(t *testing.T) {
+	if _, err := DecodeToken("not-valid-base64!!"); err == nil {
+		t.Fatal("expected an error for a malformed token")
+	}
+}
+
+func TestClampPageSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want int64
+	}{
+		{"zero falls back to default", 0, DefaultPageSize},
+		{"negative falls back to default", -5, DefaultPageSize},
+		{"within range is unchanged", 25, 25},
+		{"above max is clamped", 500, MaxPageSize},
+		{"exactly max is unchanged", MaxPageSize, MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClampPageSize(tt.size); got != tt.want {
+				t.Errorf("ClampPageSize(%d) = %d, want %d", tt.size, got, tt.want)
+			}
+		})
+	}
+}